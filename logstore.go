@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"hlib74/coursework/metrics"
+)
+
+// LogFormat визначає, у якому вигляді записи зберігаються у файлі логів.
+type LogFormat int
+
+const (
+	// LogFormatPlain — людинозрозумілий рядок, як і раніше.
+	LogFormatPlain LogFormat = iota
+	// LogFormatJSON — один JSON-об'єкт на рядок (newline-delimited JSON).
+	LogFormatJSON
+)
+
+// LogEntry — одна подія реєстрації пристрою, яку сервер записує у лог.
+type LogEntry struct {
+	Timestamp   time.Time `json:"timestamp"`
+	DeviceName  string    `json:"device_name"`
+	DeviceType  string    `json:"device_type"`
+	IPAddress   string    `json:"ip_address"`
+	RoutingType string    `json:"routing_type"`
+	RemoteAddr  string    `json:"remote_addr"`
+}
+
+// LogStore абстрагує збереження логів реєстрації від конкретного файлу та
+// політики ротації, щоб handleGet/handlePost/handleDelete не працювали з
+// файлами напряму.
+type LogStore interface {
+	Append(entry LogEntry) error
+	ReadAll(w io.Writer) error
+	Truncate() error
+}
+
+// RotationConfig задає ліміти ротації лог-файлу за зразком lumberjack:
+// MaxSizeBytes — поріг розміру поточного файлу, після якого відбувається
+// ротація, MaxBackups/MaxAge — скільки і як довго зберігати старі копії,
+// Compress — чи стискати ротовані файли у gzip.
+type RotationConfig struct {
+	MaxSizeBytes int64
+	MaxBackups   int
+	MaxAge       time.Duration
+	Compress     bool
+}
+
+// DefaultRotationConfig повертає розумні значення за замовчуванням для
+// демонстраційного запуску сервера.
+func DefaultRotationConfig() RotationConfig {
+	return RotationConfig{
+		MaxSizeBytes: 10 * 1024 * 1024, // 10MB
+		MaxBackups:   5,
+		MaxAge:       7 * 24 * time.Hour,
+		Compress:     true,
+	}
+}
+
+// fileLogStore — реалізація LogStore поверх локального файлу з ротацією за
+// розміром, видаленням застарілих (за MaxAge) і зайвих резервних копій та
+// опціональним стисненням старих файлів. Вік поточного файлу ротацію не
+// спричиняє.
+type fileLogStore struct {
+	mu       sync.Mutex
+	path     string
+	format   LogFormat
+	rotation RotationConfig
+	size     int64
+}
+
+// NewFileLogStore відкриває (або готує до створення) файл логів за заданим
+// шляхом і повертає LogStore, що пише у вказаному форматі та ротує файл
+// відповідно до rotation.
+func NewFileLogStore(path string, format LogFormat, rotation RotationConfig) (LogStore, error) {
+	s := &fileLogStore{path: path, format: format, rotation: rotation}
+	if info, err := os.Stat(path); err == nil {
+		s.size = info.Size()
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("не вдалося прочитати стан файлу логів: %w", err)
+	}
+	return s, nil
+}
+
+func (s *fileLogStore) Append(entry LogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	line, err := s.encode(entry)
+	if err != nil {
+		return err
+	}
+
+	if s.rotation.MaxSizeBytes > 0 && s.size+int64(len(line)) > s.rotation.MaxSizeBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("не вдалося відкрити файл логів: %w", err)
+	}
+	defer f.Close()
+
+	n, err := f.Write(line)
+	if err != nil {
+		return fmt.Errorf("не вдалося записати у файл логів: %w", err)
+	}
+	s.size += int64(n)
+	metrics.AddLogBytesWritten(int64(n))
+	return nil
+}
+
+func (s *fileLogStore) encode(entry LogEntry) ([]byte, error) {
+	switch s.format {
+	case LogFormatJSON:
+		b, err := json.Marshal(entry)
+		if err != nil {
+			return nil, fmt.Errorf("не вдалося серіалізувати запис логу: %w", err)
+		}
+		return append(b, '\n'), nil
+	default:
+		line := fmt.Sprintf("[%s] Name=%s, Type=%s, IP=%s, Routing=%s\n",
+			entry.Timestamp.Format(time.RFC3339), entry.DeviceName, entry.DeviceType, entry.IPAddress, entry.RoutingType)
+		return []byte(line), nil
+	}
+}
+
+// rotate перейменовує поточний лог-файл у файл з таймстемпом, за потреби
+// стискає його у gzip та прибирає застарілі чи зайві резервні копії.
+func (s *fileLogStore) rotate() error {
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		return nil
+	}
+
+	backupPath := fmt.Sprintf("%s.%s", s.path, time.Now().Format("20060102T150405.000000000"))
+	if err := os.Rename(s.path, backupPath); err != nil {
+		return fmt.Errorf("не вдалося ротувати файл логів: %w", err)
+	}
+	s.size = 0
+
+	if s.rotation.Compress {
+		if err := gzipAndRemove(backupPath); err != nil {
+			return err
+		}
+	}
+
+	return s.pruneBackups()
+}
+
+// gzipAndRemove стискає файл за шляхом path у path+".gz" і видаляє оригінал.
+func gzipAndRemove(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("не вдалося відкрити файл для стиснення: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return fmt.Errorf("не вдалося створити стиснутий файл: %w", err)
+	}
+	defer dst.Close()
+
+	gw := gzip.NewWriter(dst)
+	if _, err := io.Copy(gw, src); err != nil {
+		gw.Close()
+		return fmt.Errorf("не вдалося стиснути файл: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return fmt.Errorf("не вдалося закрити gzip-потік: %w", err)
+	}
+
+	return os.Remove(path)
+}
+
+// pruneBackups видаляє резервні копії, старші за MaxAge, і залишає не
+// більше MaxBackups найновіших файлів.
+func (s *fileLogStore) pruneBackups() error {
+	dir := filepath.Dir(s.path)
+	base := filepath.Base(s.path)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("не вдалося прочитати каталог логів: %w", err)
+	}
+
+	var backups []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || name == base || !strings.HasPrefix(name, base+".") {
+			continue
+		}
+		backups = append(backups, filepath.Join(dir, name))
+	}
+	sort.Strings(backups)
+
+	now := time.Now()
+	kept := backups[:0:0]
+	for _, b := range backups {
+		if s.rotation.MaxAge > 0 {
+			if info, err := os.Stat(b); err == nil && now.Sub(info.ModTime()) > s.rotation.MaxAge {
+				os.Remove(b)
+				continue
+			}
+		}
+		kept = append(kept, b)
+	}
+
+	if s.rotation.MaxBackups > 0 && len(kept) > s.rotation.MaxBackups {
+		for _, b := range kept[:len(kept)-s.rotation.MaxBackups] {
+			os.Remove(b)
+		}
+	}
+
+	return nil
+}
+
+func (s *fileLogStore) ReadAll(w io.Writer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		_, werr := w.Write([]byte("Файл логів порожній або не існує"))
+		return werr
+	}
+	if err != nil {
+		return fmt.Errorf("не вдалося прочитати файл логів: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(w, bufio.NewReader(f)); err != nil {
+		return fmt.Errorf("не вдалося прочитати файл логів: %w", err)
+	}
+	return nil
+}
+
+func (s *fileLogStore) Truncate() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Truncate(s.path, 0); err != nil {
+		// Якщо файл не існує, це технічно успіх для "очищення".
+		if os.IsNotExist(err) {
+			s.size = 0
+			return nil
+		}
+		return fmt.Errorf("не вдалося очистити файл логів: %w", err)
+	}
+	s.size = 0
+	return nil
+}
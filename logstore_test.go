@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestEntry() LogEntry {
+	return LogEntry{
+		Timestamp:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		DeviceName:  "pc1",
+		DeviceType:  "pc",
+		IPAddress:   "10.0.0.1",
+		RoutingType: "Static",
+		RemoteAddr:  "10.0.0.1:12345",
+	}
+}
+
+func TestFileLogStoreAppendAndReadAllPlain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	s, err := NewFileLogStore(path, LogFormatPlain, RotationConfig{})
+	if err != nil {
+		t.Fatalf("NewFileLogStore: %v", err)
+	}
+
+	if err := s.Append(newTestEntry()); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := s.ReadAll(&buf); err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Name=pc1, Type=pc, IP=10.0.0.1, Routing=Static") {
+		t.Fatalf("вміст = %q, не містить очікуваного рядка", buf.String())
+	}
+}
+
+func TestFileLogStoreAppendJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	s, err := NewFileLogStore(path, LogFormatJSON, RotationConfig{})
+	if err != nil {
+		t.Fatalf("NewFileLogStore: %v", err)
+	}
+
+	if err := s.Append(newTestEntry()); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := s.ReadAll(&buf); err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"device_name":"pc1"`) {
+		t.Fatalf("вміст = %q, не містить очікуваного JSON-поля", buf.String())
+	}
+}
+
+func TestFileLogStoreReadAllMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	s, err := NewFileLogStore(path, LogFormatPlain, RotationConfig{})
+	if err != nil {
+		t.Fatalf("NewFileLogStore: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := s.ReadAll(&buf); err != nil {
+		t.Fatalf("ReadAll неіснуючого файлу не мало повернути помилку: %v", err)
+	}
+	if buf.Len() == 0 {
+		t.Fatal("очікувалось повідомлення-заглушку для порожнього/неіснуючого файлу")
+	}
+}
+
+func TestFileLogStoreTruncate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	s, err := NewFileLogStore(path, LogFormatPlain, RotationConfig{})
+	if err != nil {
+		t.Fatalf("NewFileLogStore: %v", err)
+	}
+
+	if err := s.Append(newTestEntry()); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := s.Truncate(); err != nil {
+		t.Fatalf("Truncate: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if info.Size() != 0 {
+		t.Fatalf("розмір файлу після Truncate = %d, хочемо 0", info.Size())
+	}
+}
+
+func TestFileLogStoreRotatesAndCompressesOnSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "server.log")
+	rotation := RotationConfig{MaxSizeBytes: 1, MaxBackups: 5, Compress: true}
+
+	s, err := NewFileLogStore(path, LogFormatPlain, rotation)
+	if err != nil {
+		t.Fatalf("NewFileLogStore: %v", err)
+	}
+
+	if err := s.Append(newTestEntry()); err != nil {
+		t.Fatalf("перший Append: %v", err)
+	}
+	if err := s.Append(newTestEntry()); err != nil {
+		t.Fatalf("другий Append (мав спричинити ротацію): %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+
+	var foundGzipBackup bool
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), "server.log.") && strings.HasSuffix(e.Name(), ".gz") {
+			foundGzipBackup = true
+		}
+	}
+	if !foundGzipBackup {
+		t.Fatalf("очікувався стиснутий backup-файл серед %v", entries)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("поточний файл логів має існувати після ротації: %v", err)
+	}
+}
+
+func TestPruneBackupsRespectsMaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "server.log")
+
+	if err := os.WriteFile(path, []byte("current"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		backup := filepath.Join(dir, "server.log.2026010"+string(rune('1'+i)))
+		if err := os.WriteFile(backup, []byte("backup"), 0644); err != nil {
+			t.Fatalf("WriteFile backup: %v", err)
+		}
+	}
+
+	s := &fileLogStore{path: path, rotation: RotationConfig{MaxBackups: 1}}
+	if err := s.pruneBackups(); err != nil {
+		t.Fatalf("pruneBackups: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+
+	var backups int
+	for _, e := range entries {
+		if e.Name() != "server.log" {
+			backups++
+		}
+	}
+	if backups != 1 {
+		t.Fatalf("залишилось %d backup-файлів, хочемо 1 (MaxBackups)", backups)
+	}
+}
@@ -2,14 +2,20 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
-	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
-	"sync"
 	"time"
+
+	"hlib74/coursework/metrics"
+	"hlib74/coursework/middleware"
+	"hlib74/coursework/simulator"
+	"hlib74/coursework/store"
+	"hlib74/coursework/tcpserver"
 )
 
 // DevicePayload представляє JSON-навантаження для POST-запиту
@@ -20,19 +26,109 @@ type DevicePayload struct {
 	RoutingType string `json:"RoutingType"`
 }
 
+// ServerConfig задає параметри запуску сервера: обраний формат і ліміти
+// ротації логів та бекенд інвентаризації пристроїв.
+type ServerConfig struct {
+	LogFileName string
+	LogFormat   LogFormat
+	Rotation    RotationConfig
+
+	// StorageBackend обирає реалізацію store.Store: "sqlite" (за
+	// замовчуванням) або "file" для легкого fallback у JSON-файлі.
+	StorageBackend string
+	StoragePath    string
+
+	// AuthToken, якщо не порожній, вимагається у заголовку
+	// "Authorization: Bearer <token>" для всіх запитів.
+	AuthToken string
+
+	// TCPAddr — адреса, на якій tcpserver приймає реєстрацію пристроїв
+	// лінійним протоколом, в доповнення до HTTP.
+	TCPAddr string
+}
+
+// DefaultServerConfig повертає конфігурацію сервера за замовчуванням:
+// текстовий лог server.log зі стандартними лімітами ротації, SQLite-
+// інвентаризацію в devices.db та токен авторизації з SERVER_AUTH_TOKEN.
+func DefaultServerConfig() ServerConfig {
+	return ServerConfig{
+		LogFileName:    "server.log",
+		LogFormat:      LogFormatPlain,
+		Rotation:       DefaultRotationConfig(),
+		StorageBackend: "sqlite",
+		StoragePath:    "devices.db",
+		AuthToken:      os.Getenv("SERVER_AUTH_TOKEN"),
+		TCPAddr:        ":8081",
+	}
+}
+
+// logAppenderAdapter дозволяє tcpserver писати у те саме сховище логів, що
+// й HTTP-обробники, не звʼязуючи пакет tcpserver з пакетом main.
+type logAppenderAdapter struct{}
+
+func (logAppenderAdapter) Append(e tcpserver.LogEntry) error {
+	return logStore.Append(LogEntry{
+		Timestamp:   e.Timestamp,
+		DeviceName:  e.DeviceName,
+		DeviceType:  e.DeviceType,
+		IPAddress:   e.IPAddress,
+		RoutingType: e.RoutingType,
+		RemoteAddr:  e.RemoteAddr,
+	})
+}
+
 var (
-	logFileName = "server.log"
-	fileMutex   sync.Mutex
+	logStore    LogStore
+	deviceStore store.Store
+	logger      = slog.New(slog.NewJSONHandler(os.Stdout, nil))
 )
 
+// newDeviceStore створює реалізацію store.Store відповідно до cfg.
+func newDeviceStore(cfg ServerConfig) (store.Store, error) {
+	switch cfg.StorageBackend {
+	case "file":
+		return store.NewFileStore(cfg.StoragePath)
+	default:
+		return store.NewSQLiteStore(cfg.StoragePath)
+	}
+}
+
 func main() {
+	simConfigPath := flag.String("config", "topologies.json", "шлях до JSON-конфігу топологій симуляції")
+	flag.Parse()
+
+	cfg := DefaultServerConfig()
+
+	fls, err := NewFileLogStore(cfg.LogFileName, cfg.LogFormat, cfg.Rotation)
+	if err != nil {
+		logger.Error("не вдалося ініціалізувати сховище логів", "error", err)
+		os.Exit(1)
+	}
+	logStore = fls
+
+	ds, err := newDeviceStore(cfg)
+	if err != nil {
+		logger.Error("не вдалося ініціалізувати інвентаризацію пристроїв", "error", err)
+		os.Exit(1)
+	}
+	deviceStore = ds
+
 	// Запуска сервер у горутині
-	go startServer()
+	go startServer(cfg)
+
+	tcpCtx, stopTCP := context.WithCancel(context.Background())
+	defer stopTCP()
+	tcpSrv := tcpserver.NewServer(cfg.TCPAddr, logAppenderAdapter{}, deviceStore)
+	go func() {
+		if err := tcpSrv.ListenAndServe(tcpCtx); err != nil {
+			logger.Error("TCP-сервер зупинився", "error", err)
+		}
+	}()
 
 	time.Sleep(1 * time.Second)
 
 	// Симуляція клієнта
-	runSimulation()
+	runSimulation(*simConfigPath)
 
 	fmt.Println("\nСервер продовжує працювати. Натисніть Enter щоб вийти...")
 	fmt.Scanln()
@@ -40,44 +136,47 @@ func main() {
 
 // --- РЕАЛІЗАЦІЯ СЕРВЕРА ---
 
-func startServer() {
-	http.HandleFunc("/", handleRoot)
+func startServer(cfg ServerConfig) {
+	handler := buildHandler(cfg)
 	fmt.Println("Сервер слухає на порту 8080...")
-	if err := http.ListenAndServe(":8080", nil); err != nil {
-		log.Fatalf("Сервер не вдалося запустити: %v", err)
+	if err := http.ListenAndServe(":8080", handler); err != nil {
+		logger.Error("сервер не вдалося запустити", "error", err)
+		os.Exit(1)
 	}
 }
 
-func handleRoot(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		handleGet(w, r)
-	case http.MethodPost:
-		handlePost(w, r)
-	case http.MethodDelete:
-		handleDelete(w, r)
-	default:
-		http.Error(w, "Метод не дозволено", http.StatusMethodNotAllowed)
-	}
+// buildHandler реєструє маршрути на http.ServeMux (патерни Go 1.22,
+// напр. "POST /devices") і огортає їх ланцюжком middleware.
+func buildHandler(cfg ServerConfig) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /logs", handleGet)
+	mux.HandleFunc("DELETE /logs", handleDelete)
+	mux.HandleFunc("POST /devices", handlePost)
+	mux.HandleFunc("GET /devices", handleDevicesCollection)
+	mux.HandleFunc("GET /devices/{ip}", handleDeviceGet)
+	mux.HandleFunc("DELETE /devices/{ip}", handleDeviceDelete)
+	mux.Handle("GET /metrics", metrics.Handler())
+
+	chain := middleware.Chain(
+		middleware.RequestID(),
+		middleware.Recover(logger),
+		middleware.AccessLog(logger),
+		middleware.Metrics(),
+		middleware.Gzip(),
+		middleware.TokenAuth(cfg.AuthToken),
+	)
+	return chain(mux)
 }
 
 func handleGet(w http.ResponseWriter, r *http.Request) {
-	fileMutex.Lock()
-	defer fileMutex.Unlock()
-
-	content, err := os.ReadFile(logFileName)
-	if os.IsNotExist(err) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("Файл логів порожній або не існує"))
-		return
-	}
-	if err != nil {
+	var buf bytes.Buffer
+	if err := logStore.ReadAll(&buf); err != nil {
+		logger.Error("не вдалося прочитати файл логів", "error", err)
 		http.Error(w, "Не вдалося прочитати файл логів", http.StatusInternalServerError)
 		return
 	}
-
 	w.WriteHeader(http.StatusOK)
-	w.Write(content)
+	w.Write(buf.Bytes())
 }
 
 func handlePost(w http.ResponseWriter, r *http.Request) {
@@ -87,122 +186,136 @@ func handlePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	timestamp := time.Now().Format(time.RFC3339)
-	logEntry := fmt.Sprintf("[%s] Name=%s, Type=%s, IP=%s, Routing=%s\n",
-		timestamp, payload.DeviceName, payload.DeviceType, payload.IPAddress, payload.RoutingType)
-
-	fileMutex.Lock()
-	defer fileMutex.Unlock()
-
-	f, err := os.OpenFile(logFileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		http.Error(w, "Не вдалося відкрити файл логів", http.StatusInternalServerError)
-		return
+	entry := LogEntry{
+		Timestamp:   time.Now(),
+		DeviceName:  payload.DeviceName,
+		DeviceType:  payload.DeviceType,
+		IPAddress:   payload.IPAddress,
+		RoutingType: payload.RoutingType,
+		RemoteAddr:  r.RemoteAddr,
 	}
-	defer f.Close()
 
-	if _, err := f.WriteString(logEntry); err != nil {
+	if err := logStore.Append(entry); err != nil {
 		http.Error(w, "Не вдалося записати у файл логів", http.StatusInternalServerError)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte("Дані успішно записано"))
-}
+	device := store.Device{
+		IPAddress:   payload.IPAddress,
+		Name:        payload.DeviceName,
+		Type:        payload.DeviceType,
+		RoutingType: payload.RoutingType,
+	}
+	if err := deviceStore.Upsert(device); err != nil {
+		http.Error(w, "Не вдалося оновити інвентаризацію пристроїв", http.StatusInternalServerError)
+		return
+	}
+	if err := deviceStore.AppendEvent(store.Event{
+		Action:    "register",
+		IPAddress: payload.IPAddress,
+		Detail:    fmt.Sprintf("Name=%s, Type=%s, Routing=%s", payload.DeviceName, payload.DeviceType, payload.RoutingType),
+	}); err != nil {
+		logger.Error("не вдалося записати подію інвентаризації", "error", err)
+	}
 
-func handleDelete(w http.ResponseWriter, r *http.Request) {
-	fileMutex.Lock()
-	defer fileMutex.Unlock()
-
-	if err := os.Truncate(logFileName, 0); err != nil {
-		// Якщо файл не існує, це технічно успіх для "очищення"
-		if !os.IsNotExist(err) {
-			http.Error(w, "Не вдалося очистити файл логів", http.StatusInternalServerError)
-			return
-		}
+	metrics.IncDeviceRegistered(payload.DeviceType)
+	if r.ContentLength >= 0 {
+		metrics.ObservePayloadSize(int(r.ContentLength))
 	}
 
 	w.WriteHeader(http.StatusOK)
-	w.Write([]byte("Файл логів очищено"))
-}
-
-// --- СИМУЛЯЦІЯ КЛІЄНТА ---
-
-type NetworkConfig struct {
-	SubnetPrefix string
-	PC           int
-	Laptop       int
-	Printer      int
+	w.Write([]byte("Дані успішно записано"))
 }
 
-func runSimulation() {
-	networks := []NetworkConfig{
-		{SubnetPrefix: "192.168.1", PC: 3, Laptop: 1, Printer: 1},
-		{SubnetPrefix: "192.168.2", PC: 3, Laptop: 1, Printer: 1},
+// handleDevicesCollection обробляє GET /devices?type=&subnet=&routing=
+// — список пристроїв з опціональними фільтрами.
+func handleDevicesCollection(w http.ResponseWriter, r *http.Request) {
+	filter := store.Filter{
+		Type:    r.URL.Query().Get("type"),
+		Subnet:  r.URL.Query().Get("subnet"),
+		Routing: r.URL.Query().Get("routing"),
 	}
 
-	client := &http.Client{Timeout: 5 * time.Second}
-	serverURL := "http://localhost:8080/"
-
-	fmt.Println("Запуск симуляції мережі...")
-
-	for netIdx, netArg := range networks {
-		devices := []struct {
-			Type  string
-			Count int
-		}{
-			{"PC", netArg.PC},
-			{"Laptop", netArg.Laptop},
-			{"Printer", netArg.Printer},
-		}
+	devices, err := deviceStore.List(filter)
+	if err != nil {
+		logger.Error("не вдалося отримати список пристроїв", "error", err)
+		http.Error(w, "Не вдалося отримати список пристроїв", http.StatusInternalServerError)
+		return
+	}
 
-		ipCounter := 10
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(devices); err != nil {
+		logger.Error("не вдалося закодувати список пристроїв", "error", err)
+	}
+}
 
-		for _, devGroup := range devices {
-			for i := 1; i <= devGroup.Count; i++ {
-				deviceName := fmt.Sprintf("%s%d_%d", devGroup.Type, i, netIdx+1)
-				ipAddress := fmt.Sprintf("%s.%d", netArg.SubnetPrefix, ipCounter)
-				routingType := "Static"
-				if (ipCounter % 2) == 0 {
-					routingType = "Dynamic"
-				}
+// handleDeviceGet обробляє GET /devices/{ip}.
+func handleDeviceGet(w http.ResponseWriter, r *http.Request) {
+	ip := r.PathValue("ip")
 
-				payload := DevicePayload{
-					DeviceName:  deviceName,
-					DeviceType:  devGroup.Type,
-					IPAddress:   ipAddress,
-					RoutingType: routingType,
-				}
+	device, ok, err := deviceStore.Get(ip)
+	if err != nil {
+		logger.Error("не вдалося отримати пристрій", "ip", ip, "error", err)
+		http.Error(w, "Не вдалося отримати пристрій", http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		http.Error(w, "Пристрій не знайдено", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(device); err != nil {
+		logger.Error("не вдалося закодувати пристрій", "error", err)
+	}
+}
 
-				sendPostRequest(client, serverURL, payload)
+// handleDeviceDelete обробляє DELETE /devices/{ip}.
+func handleDeviceDelete(w http.ResponseWriter, r *http.Request) {
+	ip := r.PathValue("ip")
 
-				ipCounter++
-			}
-		}
+	if err := deviceStore.Delete(ip); err != nil {
+		logger.Error("не вдалося видалити пристрій", "ip", ip, "error", err)
+		http.Error(w, "Не вдалося видалити пристрій", http.StatusInternalServerError)
+		return
 	}
-
-	fmt.Println("Симуляцію завершено.")
+	if err := deviceStore.AppendEvent(store.Event{Action: "delete", IPAddress: ip}); err != nil {
+		logger.Error("не вдалося записати подію інвентаризації", "error", err)
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Пристрій видалено"))
 }
 
-func sendPostRequest(client *http.Client, url string, data DevicePayload) {
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		log.Printf("Помилка маршалізації JSON для %s: %v", data.DeviceName, err)
+func handleDelete(w http.ResponseWriter, r *http.Request) {
+	if err := logStore.Truncate(); err != nil {
+		http.Error(w, "Не вдалося очистити файл логів", http.StatusInternalServerError)
 		return
 	}
 
-	resp, err := client.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Файл логів очищено"))
+}
+
+// --- СИМУЛЯЦІЯ КЛІЄНТА ---
+
+// runSimulation завантажує топології з configPath і проганяє їх через
+// simulator.Runner, друкуючи агрегований звіт по завершенню.
+func runSimulation(configPath string) {
+	fmt.Println("Запуск симуляції мережі...")
+
+	simCfg, err := simulator.LoadConfig(configPath)
 	if err != nil {
-		log.Printf("Помилка надсилання POST для %s: %v", data.DeviceName, err)
+		logger.Error("не вдалося завантажити конфіг симуляції", "error", err)
 		return
 	}
-	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	report := simulator.NewRunner(simCfg).Run(ctx)
 
-	if resp.StatusCode == http.StatusOK {
-		fmt.Printf("[УСПІХ] Надіслано %s (%s): Відповідь сервера: %s\n", data.DeviceName, data.IPAddress, string(body))
-	} else {
-		fmt.Printf("[НЕВДАЧА] Надіслано %s (%s): Сервер повернув %d\n", data.DeviceName, data.IPAddress, resp.StatusCode)
+	fmt.Printf("Симуляцію завершено: %d успішно, %d невдало з %d пристроїв.\n",
+		report.Succeeded, report.Failed, report.Total)
+	for _, failure := range report.FailedDevices {
+		fmt.Printf("[НЕВДАЧА] %s\n", failure)
 	}
 }
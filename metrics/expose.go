@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync/atomic"
+)
+
+// Handler повертає обробник GET /metrics у текстовому форматі Prometheus
+// exposition.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		if err := WriteExpositionFormat(w); err != nil {
+			http.Error(w, "Не вдалося сформувати метрики", http.StatusInternalServerError)
+		}
+	})
+}
+
+// WriteExpositionFormat пише всі зареєстровані метрики у w у текстовому
+// форматі Prometheus exposition.
+func WriteExpositionFormat(w io.Writer) error {
+	if err := writeRequestCounters(w); err != nil {
+		return err
+	}
+	if err := writeDeviceCounters(w); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "# TYPE log_bytes_written_total counter\nlog_bytes_written_total %d\n",
+		atomic.LoadInt64(&logBytesWritten)); err != nil {
+		return err
+	}
+	if err := writeHistogram(w, "http_request_duration_seconds", requestLatency); err != nil {
+		return err
+	}
+	return writeHistogram(w, "http_request_payload_bytes", payloadSize)
+}
+
+func writeRequestCounters(w io.Writer) error {
+	requestsMu.Lock()
+	defer requestsMu.Unlock()
+
+	if _, err := fmt.Fprintln(w, "# TYPE http_requests_total counter"); err != nil {
+		return err
+	}
+
+	keys := make([]requestKey, 0, len(requests))
+	for k := range requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Method != keys[j].Method {
+			return keys[i].Method < keys[j].Method
+		}
+		return keys[i].Status < keys[j].Status
+	})
+
+	for _, k := range keys {
+		if _, err := fmt.Fprintf(w, "http_requests_total{method=%q,status=%q} %d\n",
+			k.Method, strconv.Itoa(k.Status), atomic.LoadInt64(requests[k])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeDeviceCounters(w io.Writer) error {
+	devicesMu.Lock()
+	defer devicesMu.Unlock()
+
+	if _, err := fmt.Fprintln(w, "# TYPE devices_registered_total counter"); err != nil {
+		return err
+	}
+
+	types := make([]string, 0, len(devicesByType))
+	for t := range devicesByType {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	for _, t := range types {
+		if _, err := fmt.Fprintf(w, "devices_registered_total{type=%q} %d\n",
+			t, atomic.LoadInt64(devicesByType[t])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeHistogram(w io.Writer, name string, h *histogram) error {
+	if _, err := fmt.Fprintf(w, "# TYPE %s histogram\n", name); err != nil {
+		return err
+	}
+	for i, le := range h.buckets {
+		if _, err := fmt.Fprintf(w, "%s_bucket{le=%q} %d\n", name, formatFloat(le), atomic.LoadInt64(&h.counts[i])); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", name, atomic.LoadInt64(&h.counts[len(h.buckets)])); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "%s_sum %f\n", name, float64(atomic.LoadInt64(&h.sumMicro))/1e6); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%s_count %d\n", name, atomic.LoadInt64(&h.count))
+	return err
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import "sync/atomic"
+
+// histogram — кумулятивна гістограма у стилі Prometheus: кожен бакет le
+// (less-or-equal) рахує всі спостереження, що не перевищують його межу.
+type histogram struct {
+	buckets  []float64
+	counts   []int64 // по одному лічильнику на buckets, плюс один для +Inf
+	sumMicro int64   // сума спостережень, помножена на 1e6, щоб уникнути float-атомиків
+	count    int64
+}
+
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{buckets: buckets, counts: make([]int64, len(buckets)+1)}
+}
+
+func (h *histogram) observe(v float64) {
+	atomic.AddInt64(&h.count, 1)
+	atomic.AddInt64(&h.sumMicro, int64(v*1e6))
+
+	for i, le := range h.buckets {
+		if v <= le {
+			atomic.AddInt64(&h.counts[i], 1)
+		}
+	}
+	atomic.AddInt64(&h.counts[len(h.buckets)], 1) // +Inf
+}
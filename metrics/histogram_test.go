@@ -0,0 +1,38 @@
+package metrics
+
+import "testing"
+
+func TestHistogramObserveBucketsAndInf(t *testing.T) {
+	h := newHistogram([]float64{1, 5, 10})
+	h.observe(0.5)
+	h.observe(3)
+	h.observe(100)
+
+	if h.count != 3 {
+		t.Fatalf("count = %d, хочемо 3", h.count)
+	}
+	if h.counts[0] != 1 {
+		t.Fatalf("бакет le=1 = %d, хочемо 1", h.counts[0])
+	}
+	if h.counts[1] != 2 {
+		t.Fatalf("бакет le=5 (кумулятивний) = %d, хочемо 2", h.counts[1])
+	}
+	if h.counts[2] != 2 {
+		t.Fatalf("бакет le=10 (кумулятивний) = %d, хочемо 2", h.counts[2])
+	}
+	if h.counts[3] != 3 {
+		t.Fatalf("бакет +Inf = %d, хочемо 3", h.counts[3])
+	}
+}
+
+func TestHistogramSum(t *testing.T) {
+	h := newHistogram([]float64{1, 5, 10})
+	h.observe(1.5)
+	h.observe(2.5)
+
+	const want = 4.0
+	got := float64(h.sumMicro) / 1e6
+	if diff := got - want; diff > 1e-6 || diff < -1e-6 {
+		t.Fatalf("sum = %v, хочемо %v", got, want)
+	}
+}
@@ -0,0 +1,72 @@
+// Package metrics збирає лічильники та гістограми сервера напряму через
+// sync/atomic (без важких залежностей) і віддає їх у текстовому форматі
+// Prometheus exposition через WriteExpositionFormat.
+package metrics
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type requestKey struct {
+	Method string
+	Status int
+}
+
+var (
+	requestsMu sync.Mutex
+	requests   = map[requestKey]*int64{}
+
+	devicesMu     sync.Mutex
+	devicesByType = map[string]*int64{}
+
+	logBytesWritten int64
+
+	requestLatency = newHistogram([]float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5})
+	payloadSize    = newHistogram([]float64{64, 256, 1024, 4096, 16384, 65536})
+)
+
+// IncRequest збільшує лічильник запитів http_requests_total{method,status}.
+func IncRequest(method string, status int) {
+	key := requestKey{Method: method, Status: status}
+
+	requestsMu.Lock()
+	counter, ok := requests[key]
+	if !ok {
+		counter = new(int64)
+		requests[key] = counter
+	}
+	requestsMu.Unlock()
+
+	atomic.AddInt64(counter, 1)
+}
+
+// IncDeviceRegistered збільшує лічильник зареєстрованих пристроїв за типом.
+func IncDeviceRegistered(deviceType string) {
+	devicesMu.Lock()
+	counter, ok := devicesByType[deviceType]
+	if !ok {
+		counter = new(int64)
+		devicesByType[deviceType] = counter
+	}
+	devicesMu.Unlock()
+
+	atomic.AddInt64(counter, 1)
+}
+
+// AddLogBytesWritten додає n до загальної кількості байтів, записаних у
+// лог-файл.
+func AddLogBytesWritten(n int64) {
+	atomic.AddInt64(&logBytesWritten, n)
+}
+
+// ObserveRequestLatency фіксує тривалість обробки HTTP-запиту.
+func ObserveRequestLatency(d time.Duration) {
+	requestLatency.observe(d.Seconds())
+}
+
+// ObservePayloadSize фіксує розмір тіла запиту в байтах.
+func ObservePayloadSize(bytes int) {
+	payloadSize.observe(float64(bytes))
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import "net/http"
+
+// TokenAuth вимагає заголовок "Authorization: Bearer <token>", що
+// збігається з token. Порожній token вимикає перевірку — зручно для
+// локального запуску без налаштованого секрету.
+func TokenAuth(token string) Middleware {
+	return func(next http.Handler) http.Handler {
+		if token == "" {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer "+token {
+				http.Error(w, "Невірний або відсутній токен авторизації", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
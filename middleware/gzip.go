@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+)
+
+// gzipResponseWriter обгортає http.ResponseWriter, пропускаючи записи
+// через gzip.Writer. Заголовки (зокрема Content-Type) надсилаються лише
+// після першого Write, щоб сніфінг типу відбувався на нестиснутих
+// байтах — net/http визначає Content-Type через http.DetectContentType,
+// і якщо віддати йому вже стиснені байти, він визначить тип як
+// application/x-gzip замість справжнього типу тіла відповіді.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz            *gzip.Writer
+	statusCode    int
+	headerWritten bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	w.statusCode = status
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !w.headerWritten {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		if w.statusCode == 0 {
+			w.statusCode = http.StatusOK
+		}
+		w.ResponseWriter.WriteHeader(w.statusCode)
+		w.headerWritten = true
+	}
+	return w.gz.Write(b)
+}
+
+// flushHeader надсилає заголовки, якщо next.ServeHTTP завершився, так і
+// не викликавши Write (наприклад, відповідь без тіла).
+func (w *gzipResponseWriter) flushHeader() {
+	if !w.headerWritten {
+		if w.statusCode == 0 {
+			w.statusCode = http.StatusOK
+		}
+		w.ResponseWriter.WriteHeader(w.statusCode)
+		w.headerWritten = true
+	}
+}
+
+// Gzip стискає тіло відповіді, коли клієнт оголошує підтримку
+// Accept-Encoding: gzip.
+func Gzip() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			gz := gzip.NewWriter(w)
+			defer gz.Close()
+
+			gzw := &gzipResponseWriter{ResponseWriter: w, gz: gz}
+			next.ServeHTTP(gzw, r)
+			gzw.flushHeader()
+		})
+	}
+}
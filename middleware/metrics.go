@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"hlib74/coursework/metrics"
+)
+
+// Metrics обгортає обробник лічильником запитів (за методом і статусом) і
+// гістограмою тривалості обробки з пакету metrics.
+func Metrics() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w}
+
+			next.ServeHTTP(rec, r)
+
+			metrics.IncRequest(r.Method, rec.status)
+			metrics.ObserveRequestLatency(time.Since(start))
+		})
+	}
+}
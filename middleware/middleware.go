@@ -0,0 +1,21 @@
+// Package middleware надає композиційні обгортки над http.Handler —
+// логування доступу, gzip-стиснення відповідей, відновлення після паніки
+// та перевірку токена — які можна незалежно тестувати та комбінувати
+// через Chain.
+package middleware
+
+import "net/http"
+
+// Middleware обгортає http.Handler додатковою поведінкою.
+type Middleware func(http.Handler) http.Handler
+
+// Chain застосовує middleware у порядку перелічення: перший переданий
+// middleware виконується першим (найзовнішній шар).
+func Chain(mws ...Middleware) Middleware {
+	return func(final http.Handler) http.Handler {
+		for i := len(mws) - 1; i >= 0; i-- {
+			final = mws[i](final)
+		}
+		return final
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// Recover перехоплює паніку в наступному обробнику, повертає клієнту 500
+// та пише структурований запис у logger замість падіння всього сервера.
+func Recover(logger *slog.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					logger.Error("паніка під час обробки запиту",
+						"request_id", RequestIDFromContext(r.Context()),
+						"method", r.Method,
+						"path", r.URL.Path,
+						"panic", rec,
+					)
+					http.Error(w, "Внутрішня помилка сервера", http.StatusInternalServerError)
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
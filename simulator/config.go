@@ -0,0 +1,67 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// DeviceGroup задає кількість пристроїв одного типу в топології.
+type DeviceGroup struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+// Topology описує одну симульовану мережу: підмережу, групи пристроїв (у
+// порядку, в якому вони нумеруються), з якої адреси починати нумерацію та
+// політику маршрутизації.
+type Topology struct {
+	SubnetPrefix  string        `json:"subnet_prefix"`
+	IPStart       int           `json:"ip_start"`
+	RoutingPolicy string        `json:"routing_policy"` // "alternate", "static" або "dynamic"
+	DeviceGroups  []DeviceGroup `json:"device_groups"`
+}
+
+// Config задає топології для симуляції та параметри виконання Runner.
+type Config struct {
+	ServerURL        string        `json:"server_url"`
+	Concurrency      int           `json:"concurrency"`
+	MaxRetries       int           `json:"max_retries"`
+	BaseBackoffMS    int           `json:"base_backoff_ms"`
+	RequestTimeoutMS int           `json:"request_timeout_ms"`
+	Topologies       []Topology    `json:"topologies"`
+	BaseBackoff      time.Duration `json:"-"`
+	RequestTimeout   time.Duration `json:"-"`
+}
+
+// LoadConfig читає та розбирає JSON-конфіг топологій за шляхом path,
+// підставляючи розумні значення за замовчуванням для відсутніх полів.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("не вдалося прочитати конфіг симуляції %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("не вдалося розібрати конфіг симуляції %s: %w", path, err)
+	}
+
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cfg.BaseBackoffMS <= 0 {
+		cfg.BaseBackoffMS = 200
+	}
+	if cfg.RequestTimeoutMS <= 0 {
+		cfg.RequestTimeoutMS = 5000
+	}
+	cfg.BaseBackoff = time.Duration(cfg.BaseBackoffMS) * time.Millisecond
+	cfg.RequestTimeout = time.Duration(cfg.RequestTimeoutMS) * time.Millisecond
+
+	return cfg, nil
+}
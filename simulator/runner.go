@@ -0,0 +1,228 @@
+// Package simulator керує симуляцією клієнтських пристроїв, що реєструють
+// себе на сервері: генерує пристрої з топологій конфігу, розсилає їх через
+// пул горутин з повторними спробами та агрегує результати у Report.
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Device — JSON-навантаження POST-запиту реєстрації, що очікує сервер.
+type Device struct {
+	DeviceName  string `json:"DeviceName"`
+	DeviceType  string `json:"DeviceType"`
+	IPAddress   string `json:"IPAddress"`
+	RoutingType string `json:"RoutingType"`
+}
+
+// Report — агреговані результати прогону симуляції.
+type Report struct {
+	Total         int
+	Succeeded     int
+	Failed        int
+	TotalLatency  time.Duration
+	MaxLatency    time.Duration
+	FailedDevices []string
+}
+
+// Runner розсилає пристрої з конфігурованих топологій на сервер, обмежуючи
+// паралелізм пулом горутин і повторюючи невдалі запити з експоненційною
+// затримкою.
+type Runner struct {
+	cfg    Config
+	client *http.Client
+}
+
+// NewRunner створює Runner з HTTP-клієнтом, налаштованим за cfg.RequestTimeout.
+func NewRunner(cfg Config) *Runner {
+	return &Runner{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.RequestTimeout},
+	}
+}
+
+// Run генерує пристрої з усіх топологій конфігу, розсилає їх пулом з
+// cfg.Concurrency горутин і повертає агрегований Report. Завершується
+// достроково, якщо ctx скасовано.
+func (r *Runner) Run(ctx context.Context) Report {
+	devices := make(chan Device)
+	go func() {
+		defer close(devices)
+		emitDevices(ctx, r.cfg.Topologies, devices)
+	}()
+
+	type result struct {
+		device  Device
+		latency time.Duration
+		err     error
+	}
+	results := make(chan result)
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for device := range devices {
+				latency, err := r.sendWithRetry(ctx, device)
+				select {
+				case results <- result{device: device, latency: latency, err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var report Report
+	for res := range results {
+		report.Total++
+		report.TotalLatency += res.latency
+		if res.latency > report.MaxLatency {
+			report.MaxLatency = res.latency
+		}
+		if res.err != nil {
+			report.Failed++
+			report.FailedDevices = append(report.FailedDevices, fmt.Sprintf("%s (%s): %v", res.device.DeviceName, res.device.IPAddress, res.err))
+			continue
+		}
+		report.Succeeded++
+	}
+
+	return report
+}
+
+// sendWithRetry надсилає device на сервер, повторюючи запит з
+// експоненційною затримкою при мережевих помилках чи відповідях 5xx: одна
+// початкова спроба і до cfg.MaxRetries повторних.
+func (r *Runner) sendWithRetry(ctx context.Context, device Device) (time.Duration, error) {
+	var lastErr error
+	var lastLatency time.Duration
+	backoff := r.cfg.BaseBackoff
+
+	for attempt := 0; attempt <= r.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return lastLatency, ctx.Err()
+			}
+			backoff *= 2
+		}
+
+		start := time.Now()
+		err := r.send(ctx, device)
+		latency := time.Since(start)
+		if err == nil {
+			return latency, nil
+		}
+
+		lastErr = err
+		lastLatency = latency
+		if !isRetryable(err) {
+			return latency, lastErr
+		}
+	}
+
+	return lastLatency, lastErr
+}
+
+// retryableError позначає помилки, на яких sendWithRetry повторює спробу
+// (мережеві збої та відповіді 5xx).
+type retryableError struct{ err error }
+
+func (e retryableError) Error() string { return e.err.Error() }
+func (e retryableError) Unwrap() error { return e.err }
+
+func isRetryable(err error) bool {
+	_, ok := err.(retryableError)
+	return ok
+}
+
+func (r *Runner) send(ctx context.Context, device Device) error {
+	jsonData, err := json.Marshal(device)
+	if err != nil {
+		return fmt.Errorf("не вдалося серіалізувати пристрій %s: %w", device.DeviceName, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.cfg.ServerURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("не вдалося створити запит для %s: %w", device.DeviceName, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return retryableError{fmt.Errorf("помилка надсилання POST для %s: %w", device.DeviceName, err)}
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 500 {
+		return retryableError{fmt.Errorf("сервер повернув %d для %s", resp.StatusCode, device.DeviceName)}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("сервер повернув %d для %s", resp.StatusCode, device.DeviceName)
+	}
+
+	return nil
+}
+
+// emitDevices генерує всі пристрої з переданих топологій у канал out,
+// зупиняючись достроково, якщо ctx скасовано.
+func emitDevices(ctx context.Context, topologies []Topology, out chan<- Device) {
+	for netIdx, topo := range topologies {
+		ipCounter := topo.IPStart
+		if ipCounter == 0 {
+			ipCounter = 10
+		}
+
+		for _, group := range topo.DeviceGroups {
+			for i := 1; i <= group.Count; i++ {
+				device := Device{
+					DeviceName:  fmt.Sprintf("%s%d_%d", group.Type, i, netIdx+1),
+					DeviceType:  group.Type,
+					IPAddress:   fmt.Sprintf("%s.%d", topo.SubnetPrefix, ipCounter),
+					RoutingType: routingFor(topo.RoutingPolicy, ipCounter),
+				}
+
+				select {
+				case out <- device:
+				case <-ctx.Done():
+					return
+				}
+
+				ipCounter++
+			}
+		}
+	}
+}
+
+// routingFor вирішує тип маршрутизації для пристрою за заданою політикою.
+// Політика "alternate" (за замовчуванням) чергує Static/Dynamic за
+// парністю IP, як і в оригінальній демонстрації.
+func routingFor(policy string, ipCounter int) string {
+	switch policy {
+	case "static":
+		return "Static"
+	case "dynamic":
+		return "Dynamic"
+	default:
+		if ipCounter%2 == 0 {
+			return "Dynamic"
+		}
+		return "Static"
+	}
+}
@@ -0,0 +1,103 @@
+package simulator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestRunner(cfg Config) *Runner {
+	if cfg.BaseBackoff == 0 {
+		cfg.BaseBackoff = time.Millisecond
+	}
+	if cfg.RequestTimeout == 0 {
+		cfg.RequestTimeout = time.Second
+	}
+	return NewRunner(cfg)
+}
+
+func TestSendWithRetrySucceedsAfterTransientFailures(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	r := newTestRunner(Config{ServerURL: srv.URL, MaxRetries: 5})
+	latency, err := r.sendWithRetry(context.Background(), Device{DeviceName: "pc1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if latency <= 0 {
+		t.Error("очікувалась ненульова тривалість успішної спроби")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("спроб = %d, хочемо 3 (2 невдалі + 1 успішна)", got)
+	}
+}
+
+func TestSendWithRetryExhaustsAndReturnsLastLatency(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	r := newTestRunner(Config{ServerURL: srv.URL, MaxRetries: 2})
+	latency, err := r.sendWithRetry(context.Background(), Device{DeviceName: "pc1"})
+	if err == nil {
+		t.Fatal("очікувалась помилка після вичерпання спроб")
+	}
+	if latency <= 0 {
+		t.Error("очікувалась тривалість останньої спроби, а не 0, після вичерпання спроб")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("спроб = %d, хочемо 3 (1 початкова + 2 повтори)", got)
+	}
+}
+
+func TestSendWithRetryDoesNotRetryNonRetryableError(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	r := newTestRunner(Config{ServerURL: srv.URL, MaxRetries: 5})
+	_, err := r.sendWithRetry(context.Background(), Device{DeviceName: "pc1"})
+	if err == nil {
+		t.Fatal("очікувалась помилка для 4xx-відповіді")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Fatalf("спроб = %d, хочемо 1 (4xx не повторюється)", got)
+	}
+}
+
+func TestSendWithRetryStopsOnContextCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := newTestRunner(Config{ServerURL: srv.URL, MaxRetries: 100, BaseBackoff: 50 * time.Millisecond})
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := r.sendWithRetry(ctx, Device{DeviceName: "pc1"})
+	if err == nil {
+		t.Fatal("очікувалась помилка скасування контексту")
+	}
+}
@@ -0,0 +1,131 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileStore — реалізація Store, що тримає інвентаризацію в пам'яті та
+// серіалізує її у JSON-файл після кожної зміни. Використовується як
+// легкий fallback замість SQLite-сховища (StorageBackend "file").
+type FileStore struct {
+	mu      sync.Mutex
+	path    string
+	devices map[string]Device
+	events  []Event
+}
+
+type fileStoreSnapshot struct {
+	Devices []Device `json:"devices"`
+	Events  []Event  `json:"events"`
+}
+
+// NewFileStore завантажує снапшот інвентаризації з path, якщо файл існує,
+// і готує сховище до подальших Upsert/Delete/AppendEvent.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path, devices: make(map[string]Device)}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileStore) load() error {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("не вдалося прочитати файл інвентаризації: %w", err)
+	}
+
+	var snap fileStoreSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("не вдалося розібрати файл інвентаризації: %w", err)
+	}
+	for _, d := range snap.Devices {
+		s.devices[d.IPAddress] = d
+	}
+	s.events = snap.Events
+	return nil
+}
+
+// persist має викликатися під утриманою мʼютексом s.mu.
+func (s *FileStore) persist() error {
+	snap := fileStoreSnapshot{Events: s.events}
+	for _, d := range s.devices {
+		snap.Devices = append(snap.Devices, d)
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("не вдалося серіалізувати інвентаризацію: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("не вдалося записати файл інвентаризації: %w", err)
+	}
+	return nil
+}
+
+func (s *FileStore) Upsert(d Device) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if existing, ok := s.devices[d.IPAddress]; ok {
+		d.FirstSeen = existing.FirstSeen
+	} else {
+		d.FirstSeen = now
+	}
+	d.LastSeen = now
+	s.devices[d.IPAddress] = d
+
+	return s.persist()
+}
+
+func (s *FileStore) Get(ip string) (Device, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.devices[ip]
+	return d, ok, nil
+}
+
+func (s *FileStore) List(f Filter) ([]Device, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []Device
+	for _, d := range s.devices {
+		if f.Match(d) {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (s *FileStore) Delete(ip string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.devices, ip)
+	return s.persist()
+}
+
+func (s *FileStore) AppendEvent(e Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+	s.events = append(s.events, e)
+	return s.persist()
+}
+
+func (s *FileStore) Close() error {
+	return nil
+}
@@ -0,0 +1,136 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore — реалізація Store поверх SQLite: таблиця devices з
+// унікальним обмеженням на ip_address та append-only таблиця events.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS devices (
+	ip_address   TEXT PRIMARY KEY,
+	name         TEXT NOT NULL,
+	type         TEXT NOT NULL,
+	routing_type TEXT NOT NULL,
+	first_seen   DATETIME NOT NULL,
+	last_seen    DATETIME NOT NULL
+);
+CREATE TABLE IF NOT EXISTS events (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	timestamp  DATETIME NOT NULL,
+	action     TEXT NOT NULL,
+	ip_address TEXT NOT NULL,
+	detail     TEXT NOT NULL
+);`
+
+// NewSQLiteStore відкриває (створюючи за потреби) SQLite-базу за шляхом
+// path і гарантує наявність схеми devices/events.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	// WAL-режим дозволяє паралельне читання під час запису, а busy_timeout
+	// змушує SQLite чекати звільнення блокування замість того, щоб одразу
+	// повертати SQLITE_BUSY — без цього конкурентні Upsert з воркер-пулу
+	// симулятора (chunk0-4) та з tcpserver (chunk0-5) регулярно падають з
+	// "database is locked". SetMaxOpenConns(1) додатково серіалізує
+	// з'єднання в межах процесу, бо драйвер modernc.org/sqlite не підтримує
+	// безпечний конкурентний запис з одного *sql.DB.
+	dsn := fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", path)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("не вдалося відкрити sqlite базу: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("не вдалося ініціалізувати схему sqlite: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Upsert(d Device) error {
+	now := time.Now()
+	if d.FirstSeen.IsZero() {
+		d.FirstSeen = now
+	}
+	d.LastSeen = now
+
+	_, err := s.db.Exec(`
+INSERT INTO devices (ip_address, name, type, routing_type, first_seen, last_seen)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT(ip_address) DO UPDATE SET
+	name = excluded.name,
+	type = excluded.type,
+	routing_type = excluded.routing_type,
+	last_seen = excluded.last_seen`,
+		d.IPAddress, d.Name, d.Type, d.RoutingType, d.FirstSeen, d.LastSeen)
+	if err != nil {
+		return fmt.Errorf("не вдалося оновити пристрій %s: %w", d.IPAddress, err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Get(ip string) (Device, bool, error) {
+	row := s.db.QueryRow(`SELECT ip_address, name, type, routing_type, first_seen, last_seen FROM devices WHERE ip_address = ?`, ip)
+
+	var d Device
+	if err := row.Scan(&d.IPAddress, &d.Name, &d.Type, &d.RoutingType, &d.FirstSeen, &d.LastSeen); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Device{}, false, nil
+		}
+		return Device{}, false, fmt.Errorf("не вдалося отримати пристрій %s: %w", ip, err)
+	}
+	return d, true, nil
+}
+
+func (s *SQLiteStore) List(f Filter) ([]Device, error) {
+	rows, err := s.db.Query(`SELECT ip_address, name, type, routing_type, first_seen, last_seen FROM devices`)
+	if err != nil {
+		return nil, fmt.Errorf("не вдалося отримати список пристроїв: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Device
+	for rows.Next() {
+		var d Device
+		if err := rows.Scan(&d.IPAddress, &d.Name, &d.Type, &d.RoutingType, &d.FirstSeen, &d.LastSeen); err != nil {
+			return nil, fmt.Errorf("не вдалося прочитати рядок пристрою: %w", err)
+		}
+		if f.Match(d) {
+			out = append(out, d)
+		}
+	}
+	return out, rows.Err()
+}
+
+func (s *SQLiteStore) Delete(ip string) error {
+	if _, err := s.db.Exec(`DELETE FROM devices WHERE ip_address = ?`, ip); err != nil {
+		return fmt.Errorf("не вдалося видалити пристрій %s: %w", ip, err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) AppendEvent(e Event) error {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+	_, err := s.db.Exec(`INSERT INTO events (timestamp, action, ip_address, detail) VALUES (?, ?, ?, ?)`,
+		e.Timestamp, e.Action, e.IPAddress, e.Detail)
+	if err != nil {
+		return fmt.Errorf("не вдалося записати подію: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
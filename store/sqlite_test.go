@@ -0,0 +1,40 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestSQLiteStoreConcurrentUpsertDoesNotLock(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "devices.db")
+	s, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("не вдалося відкрити sqlite базу: %v", err)
+	}
+	defer s.Close()
+
+	const goroutines = 20
+	var wg sync.WaitGroup
+	errs := make([]error, goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = s.Upsert(Device{
+				IPAddress: fmt.Sprintf("10.0.0.%d", i),
+				Name:      fmt.Sprintf("pc%d", i),
+				Type:      "pc",
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("goroutine %d: Upsert failed: %v", i, err)
+		}
+	}
+}
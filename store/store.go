@@ -0,0 +1,81 @@
+// Package store надає запитувану інвентаризацію мережевих пристроїв, яка
+// замінює роботу напряму з файлом логів: пристрої зберігаються з апсертом
+// за IPAddress, а кожна зміна додатково пишеться у append-only журнал подій.
+package store
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"time"
+)
+
+// ErrNotFound описує відсутність пристрою з заданою IP-адресою. Реалізації
+// Store у цьому пакеті її не повертають: Get повідомляє про відсутність
+// через ok=false, а Delete відсутнього пристрою завершується без помилки.
+var ErrNotFound = errors.New("пристрій не знайдено")
+
+// Device — запис про мережевий пристрій в інвентаризації.
+type Device struct {
+	IPAddress   string    `json:"ip_address"`
+	Name        string    `json:"name"`
+	Type        string    `json:"type"`
+	RoutingType string    `json:"routing_type"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+}
+
+// Event — запис у append-only журналі подій інвентаризації (реєстрація,
+// оновлення чи видалення пристрою).
+type Event struct {
+	Timestamp time.Time `json:"timestamp"`
+	Action    string    `json:"action"`
+	IPAddress string    `json:"ip_address"`
+	Detail    string    `json:"detail"`
+}
+
+// Filter обмежує список пристроїв, що повертає List. Порожнє поле означає
+// відсутність обмеження за цим критерієм.
+type Filter struct {
+	Type    string
+	Subnet  string // CIDR, напр. "192.168.1.0/24"
+	Routing string
+}
+
+// Match повідомляє, чи задовольняє пристрій d усі непорожні умови фільтра.
+func (f Filter) Match(d Device) bool {
+	if f.Type != "" && !strings.EqualFold(f.Type, d.Type) {
+		return false
+	}
+	if f.Routing != "" && !strings.EqualFold(f.Routing, d.RoutingType) {
+		return false
+	}
+	if f.Subnet != "" {
+		_, ipnet, err := net.ParseCIDR(f.Subnet)
+		if err != nil {
+			return false
+		}
+		ip := net.ParseIP(d.IPAddress)
+		if ip == nil || !ipnet.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// Store — інвентаризація пристроїв з підтримкою запитів за типом,
+// підмережею та способом маршрутизації.
+type Store interface {
+	// Upsert додає пристрій або оновлює існуючий запис за IPAddress.
+	Upsert(d Device) error
+	// Get повертає пристрій за IP-адресою; ok=false, якщо його немає.
+	Get(ip string) (d Device, ok bool, err error)
+	// List повертає пристрої, що задовольняють фільтр f.
+	List(f Filter) ([]Device, error)
+	// Delete прибирає пристрій з інвентаризації.
+	Delete(ip string) error
+	// AppendEvent додає запис у журнал подій.
+	AppendEvent(e Event) error
+	// Close звільняє ресурси сховища (файли, з'єднання з БД тощо).
+	Close() error
+}
@@ -0,0 +1,66 @@
+package store
+
+import "testing"
+
+func TestFilterMatchType(t *testing.T) {
+	f := Filter{Type: "router"}
+	if !f.Match(Device{Type: "Router"}) {
+		t.Error("очікувалось співпадіння без урахування регістру")
+	}
+	if f.Match(Device{Type: "switch"}) {
+		t.Error("очікувалась відсутність співпадіння для іншого типу")
+	}
+}
+
+func TestFilterMatchRouting(t *testing.T) {
+	f := Filter{Routing: "static"}
+	if !f.Match(Device{RoutingType: "Static"}) {
+		t.Error("очікувалось співпадіння без урахування регістру")
+	}
+	if f.Match(Device{RoutingType: "Dynamic"}) {
+		t.Error("очікувалась відсутність співпадіння для іншого типу маршрутизації")
+	}
+}
+
+func TestFilterMatchSubnet(t *testing.T) {
+	f := Filter{Subnet: "192.168.1.0/24"}
+	if !f.Match(Device{IPAddress: "192.168.1.42"}) {
+		t.Error("очікувалось співпадіння IP у межах підмережі")
+	}
+	if f.Match(Device{IPAddress: "192.168.2.42"}) {
+		t.Error("очікувалась відсутність співпадіння для IP поза підмережею")
+	}
+}
+
+func TestFilterMatchInvalidSubnet(t *testing.T) {
+	f := Filter{Subnet: "not-a-cidr"}
+	if f.Match(Device{IPAddress: "192.168.1.42"}) {
+		t.Error("невалідний CIDR має призводити до відсутності співпадіння")
+	}
+}
+
+func TestFilterMatchInvalidIP(t *testing.T) {
+	f := Filter{Subnet: "192.168.1.0/24"}
+	if f.Match(Device{IPAddress: "not-an-ip"}) {
+		t.Error("невалідна IP-адреса пристрою має призводити до відсутності співпадіння")
+	}
+}
+
+func TestFilterMatchEmptyMatchesAll(t *testing.T) {
+	f := Filter{}
+	if !f.Match(Device{IPAddress: "10.0.0.1", Type: "pc", RoutingType: "Static"}) {
+		t.Error("порожній фільтр має співпадати з будь-яким пристроєм")
+	}
+}
+
+func TestFilterMatchCombined(t *testing.T) {
+	f := Filter{Type: "pc", Subnet: "10.0.0.0/24", Routing: "dynamic"}
+	d := Device{Type: "PC", IPAddress: "10.0.0.5", RoutingType: "Dynamic"}
+	if !f.Match(d) {
+		t.Error("очікувалось співпадіння за всіма критеріями одночасно")
+	}
+	d.RoutingType = "Static"
+	if f.Match(d) {
+		t.Error("очікувалась відсутність співпадіння, якщо хоч один критерій не виконано")
+	}
+}
@@ -0,0 +1,141 @@
+// Package tcpserver приймає реєстрацію пристроїв рядковим протоколом по
+// сирому TCP-з'єднанню ("REGISTER <name> <type> <ip> <routing>\n"), пишучи
+// у ту саму інвентаризацію (store.Store) та лог реєстрацій, що й HTTP-
+// обробники сервера. Це демонструє, що сховище не прив'язане до
+// транспорту: пристрою, що не вміє HTTP, достатньо відкрити TCP-сокет.
+package tcpserver
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"hlib74/coursework/metrics"
+	"hlib74/coursework/store"
+)
+
+// LogEntry — подія реєстрації пристрою для запису у лог сервера.
+type LogEntry struct {
+	Timestamp   time.Time
+	DeviceName  string
+	DeviceType  string
+	IPAddress   string
+	RoutingType string
+	RemoteAddr  string
+}
+
+// LogAppender — мінімальний інтерфейс запису логу реєстрації, яким
+// володіє HTTP-частина сервера (main.LogStore), без залежності від
+// пакету main.
+type LogAppender interface {
+	Append(LogEntry) error
+}
+
+// connTimeout — дедлайн читання/запису на одне з'єднання.
+const connTimeout = 30 * time.Second
+
+// Server — TCP-слухач лінійного протоколу реєстрації пристроїв.
+type Server struct {
+	addr    string
+	logs    LogAppender
+	devices store.Store
+}
+
+// NewServer створює Server, що прийматиме з'єднання на addr і записуватиме
+// пристрої через logs та devices.
+func NewServer(addr string, logs LogAppender, devices store.Store) *Server {
+	return &Server{addr: addr, logs: logs, devices: devices}
+}
+
+// ListenAndServe слухає TCP на s.addr і обробляє кожне з'єднання у своїй
+// горутині, доки ctx не буде скасовано.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	var lc net.ListenConfig
+	ln, err := lc.Listen(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("не вдалося запустити TCP-сервер на %s: %w", s.addr, err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+				return fmt.Errorf("не вдалося прийняти TCP-з'єднання: %w", err)
+			}
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	for {
+		conn.SetDeadline(time.Now().Add(connTimeout))
+		if !scanner.Scan() {
+			return
+		}
+
+		response := s.handleLine(scanner.Text(), conn.RemoteAddr().String())
+		if _, err := conn.Write([]byte(response)); err != nil {
+			slog.Error("tcpserver: не вдалося відповісти", "remote_addr", conn.RemoteAddr(), "error", err)
+			return
+		}
+	}
+}
+
+// handleLine розбирає команду "REGISTER <name> <type> <ip> <routing>" і
+// повертає "OK\n" або "ERR <msg>\n".
+func (s *Server) handleLine(line, remoteAddr string) string {
+	fields := strings.Fields(line)
+	if len(fields) != 5 || fields[0] != "REGISTER" {
+		return "ERR очікується \"REGISTER <name> <type> <ip> <routing>\"\n"
+	}
+
+	name, deviceType, ip, routing := fields[1], fields[2], fields[3], fields[4]
+
+	if err := s.logs.Append(LogEntry{
+		Timestamp:   time.Now(),
+		DeviceName:  name,
+		DeviceType:  deviceType,
+		IPAddress:   ip,
+		RoutingType: routing,
+		RemoteAddr:  remoteAddr,
+	}); err != nil {
+		return fmt.Sprintf("ERR не вдалося записати лог: %v\n", err)
+	}
+
+	if err := s.devices.Upsert(store.Device{
+		IPAddress:   ip,
+		Name:        name,
+		Type:        deviceType,
+		RoutingType: routing,
+	}); err != nil {
+		return fmt.Sprintf("ERR не вдалося оновити інвентаризацію: %v\n", err)
+	}
+
+	if err := s.devices.AppendEvent(store.Event{
+		Action:    "register",
+		IPAddress: ip,
+		Detail:    fmt.Sprintf("через tcp: Name=%s, Type=%s, Routing=%s", name, deviceType, routing),
+	}); err != nil {
+		slog.Error("tcpserver: не вдалося записати подію інвентаризації", "error", err)
+	}
+
+	metrics.IncDeviceRegistered(deviceType)
+
+	return "OK\n"
+}
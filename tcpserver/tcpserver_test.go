@@ -0,0 +1,147 @@
+package tcpserver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"hlib74/coursework/store"
+)
+
+type fakeAppender struct {
+	entries []LogEntry
+	err     error
+}
+
+func (a *fakeAppender) Append(e LogEntry) error {
+	if a.err != nil {
+		return a.err
+	}
+	a.entries = append(a.entries, e)
+	return nil
+}
+
+func newTestDeviceStore(t *testing.T) store.Store {
+	t.Helper()
+	s, err := store.NewFileStore(filepath.Join(t.TempDir(), "devices.json"))
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	return s
+}
+
+func TestHandleLineRegistersDevice(t *testing.T) {
+	appender := &fakeAppender{}
+	devices := newTestDeviceStore(t)
+	s := NewServer(":0", appender, devices)
+
+	resp := s.handleLine("REGISTER pc1 pc 10.0.0.1 Static", "10.0.0.1:5555")
+	if resp != "OK\n" {
+		t.Fatalf("response = %q, хочемо %q", resp, "OK\n")
+	}
+
+	if len(appender.entries) != 1 {
+		t.Fatalf("записів логу = %d, хочемо 1", len(appender.entries))
+	}
+	entry := appender.entries[0]
+	if entry.DeviceName != "pc1" || entry.DeviceType != "pc" || entry.IPAddress != "10.0.0.1" || entry.RoutingType != "Static" {
+		t.Fatalf("запис логу = %+v, не відповідає введенню", entry)
+	}
+
+	device, ok, err := devices.Get("10.0.0.1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !ok {
+		t.Fatal("пристрій не знайдено в інвентаризації після REGISTER")
+	}
+	if device.Name != "pc1" || device.Type != "pc" || device.RoutingType != "Static" {
+		t.Fatalf("пристрій = %+v, не відповідає введенню", device)
+	}
+}
+
+func TestHandleLineMalformedCommand(t *testing.T) {
+	s := NewServer(":0", &fakeAppender{}, newTestDeviceStore(t))
+
+	cases := []string{
+		"REGISTER pc1 pc 10.0.0.1", // замало полів
+		"PING",
+		"",
+		"REGISTER pc1 pc 10.0.0.1 Static extra",
+	}
+	for _, line := range cases {
+		resp := s.handleLine(line, "10.0.0.1:5555")
+		if resp == "OK\n" {
+			t.Errorf("для некоректного рядка %q очікувалась помилка, отримали OK", line)
+		}
+	}
+}
+
+func TestHandleLineLogAppendFailure(t *testing.T) {
+	appender := &fakeAppender{err: errors.New("диск заповнено")}
+	s := NewServer(":0", appender, newTestDeviceStore(t))
+
+	resp := s.handleLine("REGISTER pc1 pc 10.0.0.1 Static", "10.0.0.1:5555")
+	if resp == "OK\n" {
+		t.Fatal("очікувалась помилка, коли LogAppender.Append повертає помилку")
+	}
+}
+
+func TestListenAndServeHandlesRegisterOverTCP(t *testing.T) {
+	appender := &fakeAppender{}
+	devices := newTestDeviceStore(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	s := NewServer(addr, appender, devices)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.ListenAndServe(ctx) }()
+
+	var conn net.Conn
+	for i := 0; i < 50; i++ {
+		conn, err = net.Dial("tcp", addr)
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("не вдалося підключитися до tcpserver: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("REGISTER laptop1 laptop 10.0.0.2 Dynamic\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		t.Fatalf("ReadString: %v", err)
+	}
+	if reply != "OK\n" {
+		t.Fatalf("відповідь = %q, хочемо %q", reply, "OK\n")
+	}
+
+	cancel()
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("ListenAndServe повернув помилку після скасування контексту: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("ListenAndServe не завершився після скасування контексту")
+	}
+}